@@ -12,11 +12,32 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+import json
 import os
 
 
+def _load_profiles(name: str) -> dict:
+    """Parse an env var holding a JSON object of named settings objects."""
+    raw = os.getenv(name, "")
+    if not raw:
+        return {}
+    try:
+        profiles = json.loads(raw)
+    except json.JSONDecodeError as e:
+        raise ValueError(f"{name} is not valid JSON: {e}") from e
+    if not isinstance(profiles, dict) or not all(
+        isinstance(p, dict) for p in profiles.values()
+    ):
+        raise ValueError(
+            f'{name} must map names to JSON objects, e.g. {{"v2": {{"model": "m"}}}}'
+        )
+    return profiles
+
+
 class Settings:
     whisper_model = os.getenv("WHISPER_MODEL", "whisper-large-v3-turbo-quantized")
+    # Empty lets Whisper auto-detect the spoken language.
+    whisper_language = os.getenv("WHISPER_LANGUAGE", "en") or None
     llamastack_url = os.getenv("LLAMASTACK_URL", "http://localhost:8321")
     agent_route = "/v1/agents"
     agent_name = (
@@ -32,6 +53,13 @@ class Settings:
     whisper_url = os.getenv("WHISPER_URL", "http://localhost:80/v1")
     mcp_url = os.getenv("MCP_URL", "http://redbank-mcp-server:8000/mcp")
     model_instructions = os.getenv("MODEL_INSTRUCTIONS", "")
+    # Extra agent configurations ({"model": ..., "instructions": ...}) that can be
+    # activated at runtime. "default" is always INFERENCE_MODEL/MODEL_INSTRUCTIONS.
+    agent_config_versions = _load_profiles("AGENT_CONFIG_VERSIONS")
+    agent_config_version = os.getenv("AGENT_CONFIG_VERSION", "default")
+    # Named agent profiles selectable per request via agent_id; each may override
+    # "model", "instructions" and "vector_store_name".
+    agent_profiles = _load_profiles("AGENT_PROFILES")
 
 
 settings = Settings()
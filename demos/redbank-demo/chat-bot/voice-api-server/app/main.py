@@ -34,6 +34,9 @@ async def health_check():
             "session_start": "POST /api/voice/session/start",
             "chat": "POST /api/voice/chat",
             "conversation_clear": "POST /api/voice/conversation/clear",
+            "config": "GET /api/voice/config",
+            "config_activate": "POST /api/voice/config/activate",
+            "config_rollback": "POST /api/voice/config/rollback",
         },
     }
 
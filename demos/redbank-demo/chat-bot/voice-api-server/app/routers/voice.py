@@ -12,9 +12,18 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
-from fastapi import APIRouter, File, UploadFile, Depends, HTTPException
-from fastapi.responses import JSONResponse, StreamingResponse
+from fastapi import (
+    APIRouter,
+    File,
+    UploadFile,
+    Depends,
+    HTTPException,
+    Header,
+    Response,
+)
+from fastapi.responses import StreamingResponse
 import base64
+import re
 from ..services.whisper_svc import WhisperService
 from ..services.responses_svc import ResponseService
 from ..services.tts_svc import TTSService
@@ -23,9 +32,22 @@ from ..config import settings
 
 router = APIRouter(prefix="/api/voice", tags=["voice"])
 
+_LANGUAGE_RE = re.compile(r"^[a-z]{2,3}$")
+
+# Major version of the JSON bodies of /transcribe, /complete and /chat, echoed in
+# the X-Voice-API-Version header. Clients asking for a newer major get the highest
+# version served here; only versions below MIN_API_VERSION are rejected. /speak
+# streams raw audio and the session/config endpoints return fixed status bodies,
+# so they are not versioned.
+API_VERSION = 1
+MIN_API_VERSION = 1
+
 _whisper = None
 _response = None
+_profile_responses = {}
 _tts = None
+_active_config = settings.agent_config_version
+_previous_config = None
 
 
 def _get_whisper():
@@ -35,16 +57,89 @@ def _get_whisper():
     return _whisper
 
 
-def _get_response():
+def _get_response(agent_id: str | None = None):
     global _response
-    if _response is None:
-        _response = ResponseService(
+    if agent_id is None:
+        if _response is None:
+            _response = ResponseService(
+                settings.llamastack_url,
+                settings.inference_model,
+                settings.vector_store_name,
+                settings.mcp_url,
+            )
+        return _response
+
+    profile = settings.agent_profiles.get(agent_id)
+    if profile is None:
+        raise HTTPException(status_code=400, detail=f"Unknown agent_id: {agent_id}")
+    if agent_id not in _profile_responses:
+        _profile_responses[agent_id] = ResponseService(
             settings.llamastack_url,
-            settings.inference_model,
-            settings.vector_store_name,
+            profile.get("model", settings.inference_model),
+            profile.get("vector_store_name", settings.vector_store_name),
             settings.mcp_url,
         )
-    return _response
+    return _profile_responses[agent_id]
+
+
+def _config_versions():
+    return {
+        "default": {
+            "model": settings.inference_model,
+            "instructions": settings.model_instructions,
+        },
+        **settings.agent_config_versions,
+    }
+
+
+if _active_config not in _config_versions():
+    raise ValueError(
+        f"AGENT_CONFIG_VERSION {_active_config!r} is not in AGENT_CONFIG_VERSIONS"
+    )
+
+
+def _active_agent_config(agent_id: str | None = None):
+    config = _config_versions()[_active_config]
+    if agent_id is not None:
+        config = {**config, **settings.agent_profiles[agent_id]}
+    return (
+        _active_config,
+        config.get("model", settings.inference_model),
+        config.get("instructions", settings.model_instructions),
+    )
+
+
+def _resolve_language(language: str | None) -> str | None:
+    if not language:
+        return settings.whisper_language
+    if not _LANGUAGE_RE.match(language):
+        raise HTTPException(
+            status_code=400, detail=f"Invalid language code: {language}"
+        )
+    return language
+
+
+def _api_version(
+    response: Response, x_voice_api_version: str | None = Header(default=None)
+) -> str:
+    version = API_VERSION
+    if x_voice_api_version:
+        try:
+            requested = int(x_voice_api_version.strip().lstrip("vV").split(".")[0])
+        except ValueError:
+            raise HTTPException(
+                status_code=400,
+                detail=f"Invalid X-Voice-API-Version: {x_voice_api_version}",
+            )
+        if requested < MIN_API_VERSION:
+            raise HTTPException(
+                status_code=406,
+                detail=f"API version {requested} is not supported, "
+                f"minimum is {MIN_API_VERSION}",
+            )
+        version = min(requested, API_VERSION)
+    response.headers["X-Voice-API-Version"] = str(version)
+    return str(version)
 
 
 def _get_tts():
@@ -55,37 +150,57 @@ def _get_tts():
 
 
 @router.post("/transcribe")
-async def transcribe(file: UploadFile = File(...), logger=Depends(get_logger)):
+async def transcribe(
+    file: UploadFile = File(...),
+    language: str | None = None,
+    api_version: str = Depends(_api_version),
+    logger=Depends(get_logger),
+):
+    language = _resolve_language(language)
     try:
         audio = await file.read()
         logger.info(f"Received audio file: {file.filename}, size: {len(audio)} bytes")
-        text, dur = _get_whisper().transcribe(audio)
+        text, dur = _get_whisper().transcribe(audio, language=language)
         logger.info(f"Transcribed {len(audio)} bytes to {len(text)} chars (dur≈{dur}s)")
-        return {"text": text, "duration": dur}
+        return {"api_version": api_version, "text": text, "duration": dur}
     except Exception as e:
         logger.error(f"Transcription error: {e}", exc_info=True)
         raise HTTPException(status_code=500, detail=str(e))
 
 
 @router.post("/complete")
-async def complete(file: UploadFile = File(...), logger=Depends(get_logger)):
+async def complete(
+    file: UploadFile = File(...),
+    language: str | None = None,
+    agent_id: str | None = None,
+    api_version: str = Depends(_api_version),
+    logger=Depends(get_logger),
+):
+    language = _resolve_language(language)
+    response_svc = _get_response(agent_id)
     audio = await file.read()
-    text, _ = _get_whisper().transcribe(audio)
+    text, _ = _get_whisper().transcribe(audio, language=language)
 
-    agent_resp = _get_response().invoke(text, settings.model_instructions)
+    config_version, model, instructions = _active_agent_config(agent_id)
+    agent_resp = response_svc.invoke(text, instructions, model=model)
     response_text = (
         agent_resp.get("output") or agent_resp.get("text") or str(agent_resp)
     )
+    logger.info(f"Response served by agent {agent_id} config {config_version}")
+    if agent_resp.get("error"):
+        logger.warning(f"Agent backend reported error: {agent_resp['error']}")
 
     wav = _get_tts().synthesize(response_text)
 
-    return JSONResponse(
-        {
-            "transcript": text,
-            "agent_text": response_text,
-            "wav_base64": base64.b64encode(wav).decode("ascii"),
-        }
-    )
+    return {
+        "api_version": api_version,
+        "agent_id": agent_id,
+        "config_version": config_version,
+        "transcript": text,
+        "agent_text": response_text,
+        "wav_base64": base64.b64encode(wav).decode("ascii"),
+        "error": agent_resp.get("error"),
+    }
 
 
 @router.post("/speak")
@@ -95,10 +210,11 @@ async def speak(text: str):
 
 
 @router.post("/session/start")
-async def start_session(logger=Depends(get_logger)):
+async def start_session(agent_id: str | None = None, logger=Depends(get_logger)):
     """Start a new agent session"""
+    response_svc = _get_response(agent_id)
     try:
-        session_id = _get_response().create_session()
+        session_id = response_svc.create_session()
         if session_id:
             logger.info(f"Created new agent session: {session_id}")
             return {"session_id": session_id, "status": "created"}
@@ -112,22 +228,34 @@ async def start_session(logger=Depends(get_logger)):
 
 
 @router.post("/chat")
-async def chat_with_agent(text: str, logger=Depends(get_logger)):
+async def chat_with_agent(
+    text: str,
+    agent_id: str | None = None,
+    api_version: str = Depends(_api_version),
+    logger=Depends(get_logger),
+):
     """Chat with the agent using text input (for testing conversation continuity)"""
+    response_svc = _get_response(agent_id)
     try:
-        tools_ctx = {"vdb_url": settings.vdb_url}
-        agent_resp = _get_response().invoke(text, tools_ctx)
+        config_version, model, instructions = _active_agent_config(agent_id)
+        agent_resp = response_svc.invoke(text, instructions, model=model)
         agent_text = (
             agent_resp.get("output") or agent_resp.get("text") or str(agent_resp)
         )
 
         logger.info(f"User: {text}")
-        logger.info(f"Agent: {agent_text}")
+        logger.info(f"Agent ({config_version}): {agent_text}")
+        if agent_resp.get("error"):
+            logger.warning(f"Agent backend reported error: {agent_resp['error']}")
 
         return {
+            "api_version": api_version,
+            "agent_id": agent_id,
+            "config_version": config_version,
             "user_input": text,
             "agent_response": agent_text,
-            "conversation_length": len(_get_response().conversation_history),
+            "conversation_length": len(response_svc.conversation_history),
+            "error": agent_resp.get("error"),
         }
     except Exception as e:
         logger.error(f"Chat error: {e}")
@@ -135,12 +263,52 @@ async def chat_with_agent(text: str, logger=Depends(get_logger)):
 
 
 @router.post("/conversation/clear")
-async def clear_conversation(logger=Depends(get_logger)):
+async def clear_conversation(
+    agent_id: str | None = None, logger=Depends(get_logger)
+):
     """Clear the conversation history"""
+    response_svc = _get_response(agent_id)
     try:
-        _get_response().clear_conversation()
+        response_svc.clear_conversation()
         logger.info("Conversation history cleared")
         return {"status": "cleared", "message": "Conversation history has been cleared"}
     except Exception as e:
         logger.error(f"Clear conversation error: {e}")
         raise HTTPException(status_code=500, detail=str(e))
+
+
+@router.get("/config")
+async def get_agent_config():
+    """List the agent configuration versions and which one is active"""
+    return {
+        "active": _active_config,
+        "previous": _previous_config,
+        "versions": sorted(_config_versions()),
+    }
+
+
+@router.post("/config/activate")
+async def activate_agent_config(version: str, logger=Depends(get_logger)):
+    """Switch the agent configuration version used for new responses"""
+    global _active_config, _previous_config
+    if version not in _config_versions():
+        raise HTTPException(
+            status_code=404, detail=f"Unknown agent config version: {version}"
+        )
+    if version != _active_config:
+        _previous_config, _active_config = _active_config, version
+    logger.info(f"Activated agent config {_active_config}")
+    return {"active": _active_config, "previous": _previous_config}
+
+
+@router.post("/config/rollback")
+async def rollback_agent_config(logger=Depends(get_logger)):
+    """Switch back to the previously active agent configuration version"""
+    global _active_config, _previous_config
+    if _previous_config is None:
+        raise HTTPException(
+            status_code=409, detail="No previous agent config version to roll back to"
+        )
+    _active_config, _previous_config = _previous_config, _active_config
+    logger.info(f"Rolled back to agent config {_active_config}")
+    return {"active": _active_config, "previous": _previous_config}
@@ -70,7 +70,9 @@ class ResponseService:
         print(f"Vector store ID: {vector_store.id}")
         return vector_store.id
 
-    def invoke(self, prompt: str, model_instructions: str) -> Dict[str, Any]:
+    def invoke(
+        self, prompt: str, model_instructions: str, model: str | None = None
+    ) -> Dict[str, Any]:
         if model_instructions == "":
             model_instructions = MODEL_INSTRUCTIONS
         print(model_instructions)
@@ -78,7 +80,7 @@ class ResponseService:
         self.conversation_history.append({"role": "user", "content": prompt})
         try:
             resp = self.client.responses.create(
-                model=self.model,
+                model=model or self.model,
                 instructions=model_instructions,
                 tools=[
                     {
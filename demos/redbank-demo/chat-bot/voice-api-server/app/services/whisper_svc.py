@@ -22,7 +22,7 @@ enabling audio transcription with performance tracking and error handling.
 import tempfile
 import os
 import time
-from openai import OpenAI
+from openai import OpenAI, NOT_GIVEN
 from typing import Tuple
 
 
@@ -89,7 +89,9 @@ class WhisperService:
         """
         self.client = OpenAI(base_url=self.whisper_url, api_key="fake")
 
-    def transcribe(self, audio_data: bytes) -> Tuple[str, float]:
+    def transcribe(
+        self, audio_data: bytes, language: str | None = None
+    ) -> Tuple[str, float]:
         """
         Transcribe audio data to text using the Whisper model.
 
@@ -102,6 +104,8 @@ class WhisperService:
                 Supported formats: mp3, mp4, mpeg, mpga, m4a, wav, webm, flac, ogg.
                 The audio will be automatically converted to Whisper-compatible
                 format (16-bit PCM, mono, 16kHz).
+            language: ISO-639 code of the spoken language (e.g. "de").
+                When None, Whisper auto-detects the language.
 
         Returns:
             A tuple containing:
@@ -139,7 +143,7 @@ class WhisperService:
                 transcript = self.client.audio.transcriptions.create(
                     model=self.model_name,  # match your deployed model name
                     file=audio_file,
-                    language="en",
+                    language=language or NOT_GIVEN,
                 )
 
             # Calculate processing duration